@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chiselstrike/iku-turso-cli/internal"
+	"github.com/chiselstrike/iku-turso-cli/internal/prompt"
+	"github.com/chiselstrike/iku-turso-cli/internal/settings"
+	"github.com/chiselstrike/iku-turso-cli/internal/turso"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupOutputFlag string
+	backupFormatFlag string
+	backupGzipFlag   bool
+)
+
+func init() {
+	dbCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.Flags().StringVarP(&backupOutputFlag, "output", "o", "", "Write the backup to this path instead of stdout.")
+	backupCmd.Flags().StringVar(&backupFormatFlag, "format", "sqlite", "Backup format: sqlite or sql.")
+	backupCmd.Flags().BoolVar(&backupGzipFlag, "gzip", false, "Compress the backup with gzip.")
+}
+
+var backupCmd = &cobra.Command{
+	Use:               "backup <database_name>",
+	Short:             "Back up a database to a local file or stdout.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: dbNameArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		name := args[0]
+
+		if backupFormatFlag != "sqlite" && backupFormatFlag != "sql" {
+			return fmt.Errorf("unknown format %q: must be sqlite or sql", backupFormatFlag)
+		}
+
+		profile := resolveProfile()
+		if err := settings.UseSessionProfile(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+		if err := applyProfileCredentials(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not read settings: %w", err)
+		}
+
+		client, err := createTursoClientFromAccessToken(true)
+		if err != nil {
+			return fmt.Errorf("could not create turso client: %w", err)
+		}
+
+		db, err := getDatabase(client, name)
+		if err != nil {
+			return err
+		}
+
+		out := os.Stdout
+		if backupOutputFlag != "" {
+			f, err := os.Create(backupOutputFlag)
+			if err != nil {
+				return fmt.Errorf("can't create %s: %w", backupOutputFlag, err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		var w io.Writer = out
+		if backupGzipFlag {
+			gz := gzip.NewWriter(out)
+			defer gz.Close()
+			w = gz
+		}
+
+		bar := prompt.StoppedSpinner(fmt.Sprintf("Backing up %s", internal.Emph(db.Name)))
+		if backupOutputFlag != "" {
+			bar.Start()
+			defer bar.Stop()
+		}
+
+		size, checksum, err := writeBackup(client, config, &db, backupFormatFlag, w)
+		if err != nil {
+			return fmt.Errorf("could not back up database %s: %w", name, err)
+		}
+		bar.Stop()
+
+		if backupOutputFlag != "" {
+			meta := settings.BackupSettings{
+				Database:  db.Name,
+				Region:    db.Region,
+				Path:      backupOutputFlag,
+				Format:    backupFormatFlag,
+				Size:      size,
+				Checksum:  checksum,
+				Timestamp: time.Now().Format(time.RFC3339),
+			}
+			config.AddBackup(db.Name, &meta)
+			fmt.Printf("Backed up database %s to %s (%d bytes).\n", internal.Emph(db.Name), internal.Emph(backupOutputFlag), size)
+		}
+
+		return nil
+	},
+}
+
+var backupListCmd = &cobra.Command{
+	Use:               "list <database_name>",
+	Short:             "List backups taken for a database.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: dbNameArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not read settings: %w", err)
+		}
+
+		backups := config.ListBackups(args[0])
+		if len(backups) == 0 {
+			fmt.Printf("No backups recorded for %s.\n", internal.Emph(args[0]))
+			return nil
+		}
+
+		for _, b := range backups {
+			fmt.Printf("%s  %s  %s  %d bytes  %s\n", b.Timestamp, b.Format, b.Path, b.Size, b.Checksum)
+		}
+
+		return nil
+	},
+}
+
+// writeBackup streams the contents of db to w in the requested format and
+// returns the number of bytes written along with a SHA-256 checksum, which
+// is recorded alongside the backup so `turso db backup list` can show it.
+func writeBackup(client *turso.Client, config *settings.Settings, db *turso.Database, format string, w io.Writer) (int64, string, error) {
+	h := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(w, h)}
+
+	switch format {
+	case "sqlite":
+		r, err := client.Databases.Export(db.Name)
+		if err != nil {
+			return 0, "", err
+		}
+		defer r.Close()
+		if _, err := io.Copy(counter, r); err != nil {
+			return 0, "", err
+		}
+	case "sql":
+		token, err := tokenFromDb(db, client)
+		if err != nil {
+			return 0, "", err
+		}
+		dump, err := dumpDatabase(config, db, token)
+		if err != nil {
+			return 0, "", err
+		}
+		if _, err := io.Copy(counter, dump); err != nil {
+			return 0, "", err
+		}
+	}
+
+	return counter.n, fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// dumpDatabase renders db's schema and table contents as a portable SQL
+// script, in the same statement-per-line shape sqlite3's .dump produces.
+func dumpDatabase(config *settings.Settings, db *turso.Database, token string) (io.Reader, error) {
+	url := getDatabaseHttpUrl(config, db)
+
+	schema, tables, err := fetchSchema(url, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var out strings.Builder
+	out.WriteString(schema)
+
+	for _, table := range tables {
+		if err := dumpTableRows(&out, url, token, table); err != nil {
+			return nil, err
+		}
+	}
+
+	return strings.NewReader(out.String()), nil
+}
+
+func fetchSchema(url, token string) (string, []string, error) {
+	resp, err := doQueryContext(context.Background(), url, token, "SELECT name, sql FROM sqlite_master WHERE type = 'table' AND sql IS NOT NULL")
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+
+	var results []QueryResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return "", nil, fmt.Errorf("could not parse schema: %w", err)
+	}
+
+	var schema strings.Builder
+	var tables []string
+	for _, res := range results {
+		if res.Error != nil {
+			return "", nil, fmt.Errorf("could not read schema: %s", res.Error.Message)
+		}
+		if res.Results == nil {
+			continue
+		}
+		for _, row := range res.Results.Rows {
+			if len(row) < 2 || row[0] == nil || row[1] == nil {
+				continue
+			}
+			tables = append(tables, fmt.Sprintf("%v", row[0]))
+			fmt.Fprintf(&schema, "%v;\n", row[1])
+		}
+	}
+
+	return schema.String(), tables, nil
+}
+
+func dumpTableRows(out *strings.Builder, url, token, table string) error {
+	resp, err := doQueryContext(context.Background(), url, token, fmt.Sprintf("SELECT * FROM %s", quoteIdent(table)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var results []QueryResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return fmt.Errorf("could not read table %s: %w", table, err)
+	}
+
+	for _, res := range results {
+		if res.Error != nil {
+			return fmt.Errorf("could not read table %s: %s", table, res.Error.Message)
+		}
+		if res.Results == nil {
+			continue
+		}
+		for _, row := range res.Results.Rows {
+			fmt.Fprintf(out, "INSERT INTO %s VALUES (%s);\n", quoteIdent(table), sqlValues(row))
+		}
+	}
+
+	return nil
+}
+
+func quoteIdent(ident string) string {
+	return fmt.Sprintf("%q", ident)
+}
+
+func sqlValues(row Row) string {
+	values := make([]string, len(row))
+	for i, col := range row {
+		values[i] = sqlLiteral(col)
+	}
+	return strings.Join(values, ", ")
+}
+
+// sqlLiteral renders v as a literal suitable for splicing into SQL text sent
+// over the query endpoint's statement-text protocol (there is no bind
+// parameter support to defer escaping to, so every value must be embedded as
+// text). Strings are quoted with doubled single quotes, the standard SQLite
+// escape.
+// A NUL byte can't survive that: it terminates a C string on the server side
+// and would silently truncate the rest of the statement, so strings
+// containing one are instead shipped as a hex blob literal cast back to
+// TEXT, which is NUL-safe by construction.
+func sqlLiteral(v interface{}) string {
+	if v == nil {
+		return "NULL"
+	}
+	switch val := v.(type) {
+	case string:
+		if strings.ContainsRune(val, 0) {
+			return fmt.Sprintf("CAST(x'%s' AS TEXT)", hex.EncodeToString([]byte(val)))
+		}
+		return "'" + strings.ReplaceAll(val, "'", "''") + "'"
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
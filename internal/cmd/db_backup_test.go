@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestSqlLiteral(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		want string
+	}{
+		{"nil", nil, "NULL"},
+		{"int", 42, "42"},
+		{"plain string", "hello", "'hello'"},
+		{"single quote", "it's", "'it''s'"},
+		{"multiple quotes", "''", "''''''"},
+		{"NUL byte", "a\x00b", "CAST(x'610062' AS TEXT)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sqlLiteral(tt.in); got != tt.want {
+				t.Errorf("sqlLiteral(%#v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,27 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/athoscouto/codename"
 	"github.com/chiselstrike/iku-turso-cli/internal"
 	"github.com/chiselstrike/iku-turso-cli/internal/prompt"
 	"github.com/chiselstrike/iku-turso-cli/internal/settings"
+	"github.com/chiselstrike/iku-turso-cli/internal/turso"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	fromDumpFlag string
+	fromURLFlag  string
+	fromDBFlag   string
 )
 
 func init() {
@@ -17,6 +29,9 @@ func init() {
 	addCanaryFlag(createCmd)
 	addDbFromFileFlag(createCmd)
 	addLocationFlag(createCmd, "Location ID. If no ID is specified, closest location to you is used by default.")
+	createCmd.Flags().StringVar(&fromDumpFlag, "from-dump", "", "Seed the new database from a SQL dump file. Use '-' to read the dump from stdin.")
+	createCmd.Flags().StringVar(&fromURLFlag, "from-url", "", "Seed the new database from a SQLite file or SQL dump downloaded from a URL.")
+	createCmd.Flags().StringVar(&fromDBFlag, "from-db", "", "Seed the new database with a snapshot of an existing database.")
 }
 
 var createCmd = &cobra.Command{
@@ -26,6 +41,16 @@ var createCmd = &cobra.Command{
 	ValidArgsFunction: noFilesArg,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
+		if err := checkSeedFlags(); err != nil {
+			return err
+		}
+		profile := resolveProfile()
+		if err := settings.UseSessionProfile(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+		if err := applyProfileCredentials(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
 		config, err := settings.ReadSettings()
 		if err != nil {
 			return err
@@ -48,6 +73,9 @@ var createCmd = &cobra.Command{
 		if region != "" && !isValidRegion(client, region) {
 			return fmt.Errorf("location '%s' is not a valid one", region)
 		}
+		if region == "" {
+			region = defaultRegionForProfile(profile)
+		}
 		if region == "" {
 			region = probeClosestRegion()
 		}
@@ -66,8 +94,15 @@ var createCmd = &cobra.Command{
 		}
 
 		dbText := ""
-		if fromFileFlag != "" {
+		switch {
+		case fromFileFlag != "":
 			dbText = fmt.Sprintf(" from file %s", internal.Emph(fromFileFlag))
+		case fromDumpFlag != "":
+			dbText = fmt.Sprintf(" from dump %s", internal.Emph(fromDumpFlag))
+		case fromURLFlag != "":
+			dbText = fmt.Sprintf(" from url %s", internal.Emph(fromURLFlag))
+		case fromDBFlag != "":
+			dbText = fmt.Sprintf(" from database %s", internal.Emph(fromDBFlag))
 		}
 
 		description := fmt.Sprintf("Creating database %s%s in %s ", internal.Emph(name), dbText, internal.Emph(regionText))
@@ -97,6 +132,20 @@ var createCmd = &cobra.Command{
 			return err
 		}
 
+		if fromDumpFlag != "" || fromURLFlag != "" || fromDBFlag != "" {
+			db := res.Database
+			token, err := tokenFromDb(&db, client)
+			if err != nil {
+				client.Databases.Delete(name)
+				return fmt.Errorf("could not create database %s: %w", name, err)
+			}
+
+			if err := seedDatabase(client, config, &db, token); err != nil {
+				client.Databases.Delete(name)
+				return fmt.Errorf("could not create database %s: %w", name, err)
+			}
+		}
+
 		bar.Stop()
 		elapsed := time.Since(start)
 		fmt.Printf("Created database %s in %s in %d seconds.\n\n", internal.Emph(name), internal.Emph(regionText), int(elapsed.Seconds()))
@@ -145,3 +194,189 @@ func getDbFile(path string) (*os.File, error) {
 
 	return f, nil
 }
+
+func checkSeedFlags() error {
+	set := 0
+	for _, flag := range []string{fromFileFlag, fromDumpFlag, fromURLFlag, fromDBFlag} {
+		if flag != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return fmt.Errorf("only one of --from-file, --from-dump, --from-url, or --from-db can be used at a time")
+	}
+	return nil
+}
+
+// seedDatabase seeds a freshly created database from --from-dump, --from-url,
+// or --from-db. --from-file is handled separately by getDbFile, since it is
+// seeded through client.Databases.Seed before the instance even exists.
+func seedDatabase(client *turso.Client, config *settings.Settings, db *turso.Database, token string) error {
+	switch {
+	case fromDumpFlag != "":
+		return seedFromDump(client, config, db, token, fromDumpFlag)
+	case fromURLFlag != "":
+		return seedFromURL(client, config, db, token, fromURLFlag)
+	case fromDBFlag != "":
+		return seedFromDatabase(client, config, db, token, fromDBFlag)
+	}
+	return nil
+}
+
+func seedFromDump(client *turso.Client, config *settings.Settings, db *turso.Database, token, path string) error {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("can't open %s: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	dump, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error while reading %s: %w", path, err)
+	}
+
+	return replayDump(config, db, token, string(dump))
+}
+
+func seedFromURL(client *turso.Client, config *settings.Settings, db *turso.Database, token, rawURL string) error {
+	tmp, err := downloadToTempFile(rawURL)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	isSQLite, err := isSQLiteFile(tmp)
+	if err != nil {
+		return fmt.Errorf("error while reading downloaded file: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if isSQLite {
+		return client.Databases.Seed(db.Name, tmp)
+	}
+
+	dump, err := io.ReadAll(tmp)
+	if err != nil {
+		return fmt.Errorf("error while reading downloaded file: %w", err)
+	}
+
+	return replayDump(config, db, token, string(dump))
+}
+
+// seedFromDatabase snapshots sourceName's schema and table data and replays
+// it onto db, reusing the same schema/row dumping helpers as `turso db
+// backup --format sql` so both code paths stay in sync.
+func seedFromDatabase(client *turso.Client, config *settings.Settings, db *turso.Database, token, sourceName string) error {
+	sourceDb, err := getDatabase(client, sourceName)
+	if err != nil {
+		return fmt.Errorf("could not find source database %s: %w", sourceName, err)
+	}
+
+	sourceToken, err := tokenFromDb(&sourceDb, client)
+	if err != nil {
+		return err
+	}
+
+	sourceUrl := getDatabaseHttpUrl(config, &sourceDb)
+
+	schema, tables, err := fetchSchema(sourceUrl, sourceToken)
+	if err != nil {
+		return fmt.Errorf("could not read schema from %s: %w", sourceName, err)
+	}
+
+	var dump strings.Builder
+	dump.WriteString(schema)
+	for _, table := range tables {
+		if err := dumpTableRows(&dump, sourceUrl, sourceToken, table); err != nil {
+			return fmt.Errorf("could not read table %s from %s: %w", table, sourceName, err)
+		}
+	}
+
+	return replayDump(config, db, token, dump.String())
+}
+
+func replayDump(config *settings.Settings, db *turso.Database, token, dump string) error {
+	url := getDatabaseHttpUrl(config, db)
+	resp, err := doQueryContext(context.Background(), url, token, dump)
+	if err != nil {
+		return fmt.Errorf("could not seed database %s: %w", db.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not seed database %s: server returned %s", db.Name, resp.Status)
+	}
+
+	return nil
+}
+
+func downloadToTempFile(rawURL string) (*os.File, error) {
+	resp, err := http.Get(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download %s: server returned %s", rawURL, resp.Status)
+	}
+
+	tmp, err := os.CreateTemp("", "turso-seed-*")
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader = resp.Body
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		progress := &downloadProgressReader{r: resp.Body, total: resp.ContentLength}
+		defer progress.done()
+		body = progress
+	}
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("could not save downloaded file: %w", err)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return tmp, nil
+}
+
+// downloadProgressReader reports bytes read from r to stderr as a
+// percentage of total, redrawing in place. total may be -1 (unknown
+// Content-Length), in which case only a running byte count is shown.
+type downloadProgressReader struct {
+	r     io.Reader
+	total int64
+	read  int64
+}
+
+func (p *downloadProgressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	p.read += int64(n)
+
+	if p.total > 0 {
+		fmt.Fprintf(os.Stderr, "\rDownloading... %d%%", p.read*100/p.total)
+	} else {
+		fmt.Fprintf(os.Stderr, "\rDownloading... %d bytes", p.read)
+	}
+
+	return n, err
+}
+
+func (p *downloadProgressReader) done() {
+	fmt.Fprintln(os.Stderr)
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/chiselstrike/iku-turso-cli/internal"
+	"github.com/chiselstrike/iku-turso-cli/internal/settings"
+	"github.com/chiselstrike/iku-turso-cli/internal/turso"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execFileFlag        string
+	execDbFlag          []string
+	execAllFlag         bool
+	execMatchFlag       string
+	execParallelismFlag int
+	execDryRunFlag      bool
+)
+
+func init() {
+	dbCmd.AddCommand(execCmd)
+	execCmd.Flags().StringVarP(&execFileFlag, "file", "f", "", "Read the SQL statement to run from a file.")
+	execCmd.Flags().StringArrayVar(&execDbFlag, "db", nil, "Database to target. Can be repeated.")
+	execCmd.Flags().BoolVar(&execAllFlag, "all", false, "Target every database in the account.")
+	execCmd.Flags().StringVar(&execMatchFlag, "match", "", "Target every database whose name matches this glob.")
+	execCmd.Flags().IntVar(&execParallelismFlag, "parallelism", 8, "Number of databases to run against concurrently.")
+	execCmd.Flags().BoolVar(&execDryRunFlag, "dry-run", false, "List the databases that would be targeted without running anything.")
+}
+
+var execCmd = &cobra.Command{
+	Use:   "exec [sql]",
+	Short: "Run a SQL statement against one or more databases.",
+	Long:  "Run a SQL statement against one or more databases, fanning out across a pool of workers.\nSelect targets with --db (repeatable), --all, or --match <glob>.",
+	Example: "turso db exec --all \"vacuum\"\n" +
+		"turso db exec --match 'tenant-*' --file migration.sql\n" +
+		"turso db exec --db a --db b \"select count(*) from users\"",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		stmt, err := execStatement(args)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTargetFlags(); err != nil {
+			return err
+		}
+
+		profile := resolveProfile()
+		if err := settings.UseSessionProfile(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+		if err := applyProfileCredentials(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+
+		client, err := createTursoClientFromAccessToken(true)
+		if err != nil {
+			return fmt.Errorf("could not create turso client: %w", err)
+		}
+
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not read settings: %w", err)
+		}
+
+		names, err := targetDatabases(client)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no databases matched --db, --all, or --match")
+		}
+		sort.Strings(names)
+
+		if execDryRunFlag {
+			for _, name := range names {
+				fmt.Println(name)
+			}
+			return nil
+		}
+
+		if execParallelismFlag < 1 {
+			return fmt.Errorf("--parallelism must be at least 1")
+		}
+
+		results := runFanOut(cmd.Context(), client, config, names, stmt, execParallelismFlag)
+
+		hadError := false
+		for _, name := range names {
+			res := results[name]
+			if res.err != nil {
+				hadError = true
+				fmt.Printf("%s\tERROR\t%s\n", internal.Emph(name), res.err)
+				continue
+			}
+			fmt.Printf("%s\tOK\t%d row(s)\n", internal.Emph(name), res.rows)
+		}
+
+		if hadError {
+			return fmt.Errorf("execution failed on one or more databases")
+		}
+
+		return nil
+	},
+}
+
+func execStatement(args []string) (string, error) {
+	if execFileFlag != "" {
+		contents, err := os.ReadFile(execFileFlag)
+		if err != nil {
+			return "", fmt.Errorf("can't read %s: %w", execFileFlag, err)
+		}
+		return string(contents), nil
+	}
+	if len(args) == 1 && args[0] != "" {
+		return args[0], nil
+	}
+	return "", fmt.Errorf("no SQL to execute: pass --file or a SQL argument")
+}
+
+// checkTargetFlags rejects ambiguous combinations of --db, --all, and
+// --match, the same way checkSeedFlags rejects ambiguous --from-* flags in
+// db_create.go. Without this, a stray --all left over in a shell history
+// would silently override --db and fan a statement out to every database in
+// the account instead of the one intended.
+func checkTargetFlags() error {
+	set := 0
+	if len(execDbFlag) > 0 {
+		set++
+	}
+	if execAllFlag {
+		set++
+	}
+	if execMatchFlag != "" {
+		set++
+	}
+	if set > 1 {
+		return fmt.Errorf("only one of --db, --all, or --match can be used at a time")
+	}
+	return nil
+}
+
+// targetDatabases resolves --db, --all, and --match into a concrete list of
+// database names. checkTargetFlags guarantees at most one of them is set.
+func targetDatabases(client *turso.Client) ([]string, error) {
+	if len(execDbFlag) > 0 {
+		return execDbFlag, nil
+	}
+
+	if !execAllFlag && execMatchFlag == "" {
+		return nil, nil
+	}
+
+	dbs, err := client.Databases.List()
+	if err != nil {
+		return nil, fmt.Errorf("could not list databases: %w", err)
+	}
+
+	if execAllFlag {
+		names := make([]string, len(dbs))
+		for i, db := range dbs {
+			names[i] = db.Name
+		}
+		return names, nil
+	}
+
+	var names []string
+	for _, db := range dbs {
+		ok, err := filepath.Match(execMatchFlag, db.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --match pattern %q: %w", execMatchFlag, err)
+		}
+		if ok {
+			names = append(names, db.Name)
+		}
+	}
+	return names, nil
+}
+
+type execResult struct {
+	rows int
+	err  error
+}
+
+// runFanOut runs stmt against every named database using a fixed-size
+// worker pool, so targeting a large fleet doesn't open hundreds of
+// connections at once.
+func runFanOut(ctx context.Context, client *turso.Client, config *settings.Settings, names []string, stmt string, parallelism int) map[string]execResult {
+	results := make(map[string]execResult, len(names))
+	var mu sync.Mutex
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				rows, err := execOne(ctx, client, config, name, stmt)
+				mu.Lock()
+				results[name] = execResult{rows: rows, err: err}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, name := range names {
+		jobs <- name
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func execOne(ctx context.Context, client *turso.Client, config *settings.Settings, name, stmt string) (int, error) {
+	db, err := databaseFromNameOrURL(name, client)
+	if err != nil {
+		return 0, err
+	}
+	if db == nil {
+		return 0, fmt.Errorf("database %s not found", name)
+	}
+
+	token, err := tokenFromDb(db, client)
+	if err != nil {
+		return 0, err
+	}
+
+	url := getDatabaseHttpUrl(config, db)
+	resp, err := doQueryContext(ctx, url, token, stmt)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var results []QueryResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return 0, fmt.Errorf("could not parse response: %w", err)
+	}
+
+	rows := 0
+	for _, res := range results {
+		if res.Error != nil {
+			return 0, fmt.Errorf("%s", res.Error.Message)
+		}
+		if res.Results != nil {
+			rows += len(res.Results.Rows)
+		}
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import "testing"
+
+func TestCheckTargetFlags(t *testing.T) {
+	reset := func() {
+		execDbFlag = nil
+		execAllFlag = false
+		execMatchFlag = ""
+	}
+	defer reset()
+
+	tests := []struct {
+		name    string
+		db      []string
+		all     bool
+		match   string
+		wantErr bool
+	}{
+		{"none set", nil, false, "", false},
+		{"only db", []string{"a"}, false, "", false},
+		{"only all", nil, true, "", false},
+		{"only match", nil, false, "tenant-*", false},
+		{"db and all", []string{"a"}, true, "", true},
+		{"db and match", []string{"a"}, false, "tenant-*", true},
+		{"all and match", nil, true, "tenant-*", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+			execDbFlag = tt.db
+			execAllFlag = tt.all
+			execMatchFlag = tt.match
+
+			err := checkTargetFlags()
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
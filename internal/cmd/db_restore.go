@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chiselstrike/iku-turso-cli/internal"
+	"github.com/chiselstrike/iku-turso-cli/internal/prompt"
+	"github.com/chiselstrike/iku-turso-cli/internal/settings"
+	"github.com/spf13/cobra"
+	"github.com/xwb1989/sqlparser"
+)
+
+var restoreBatchSizeFlag int
+
+func init() {
+	dbCmd.AddCommand(restoreCmd)
+	restoreCmd.Flags().IntVar(&restoreBatchSizeFlag, "batch-size", 100, "Number of statements to send per request while restoring.")
+}
+
+var restoreCmd = &cobra.Command{
+	Use:               "restore <database_name> [file]",
+	Short:             "Restore a database from a backup file or stdin.",
+	Long:              "Restore a database from a backup file or stdin.\nThe backup format (sqlite or sql) and gzip compression are auto-detected.",
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: dbNameArg,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		name := args[0]
+
+		if err := checkBatchSizeFlag(); err != nil {
+			return err
+		}
+
+		profile := resolveProfile()
+		if err := settings.UseSessionProfile(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+		if err := applyProfileCredentials(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+
+		var r io.Reader = os.Stdin
+		if len(args) == 2 {
+			f, err := os.Open(args[1])
+			if err != nil {
+				return fmt.Errorf("can't open %s: %w", args[1], err)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		buffered := bufio.NewReader(r)
+		if gzipped, err := isGzip(buffered); err != nil {
+			return fmt.Errorf("error while reading backup: %w", err)
+		} else if gzipped {
+			gz, err := gzip.NewReader(buffered)
+			if err != nil {
+				return fmt.Errorf("error while reading gzip backup: %w", err)
+			}
+			defer gz.Close()
+			r = gz
+		} else {
+			r = buffered
+		}
+
+		config, err := settings.ReadSettings()
+		if err != nil {
+			return fmt.Errorf("could not read settings: %w", err)
+		}
+
+		client, err := createTursoClientFromAccessToken(true)
+		if err != nil {
+			return fmt.Errorf("could not create turso client: %w", err)
+		}
+
+		db, err := getDatabase(client, name)
+		if err != nil {
+			return err
+		}
+
+		token, err := tokenFromDb(&db, client)
+		if err != nil {
+			return err
+		}
+
+		contents, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("error while reading backup: %w", err)
+		}
+
+		bar := prompt.Spinner(fmt.Sprintf("Restoring %s ", internal.Emph(db.Name)))
+		defer bar.Stop()
+
+		if isSQLiteHeader(contents) {
+			if err := client.Databases.Seed(db.Name, bytes.NewReader(contents)); err != nil {
+				return fmt.Errorf("could not restore database %s: %w", name, err)
+			}
+		} else {
+			url := getDatabaseHttpUrl(config, &db)
+			if err := restoreDump(cmd.Context(), url, token, string(contents), restoreBatchSizeFlag); err != nil {
+				return fmt.Errorf("could not restore database %s: %w", name, err)
+			}
+		}
+
+		bar.Stop()
+		fmt.Printf("Restored database %s.\n", internal.Emph(db.Name))
+		return nil
+	},
+}
+
+func isGzip(r *bufio.Reader) (bool, error) {
+	magic, err := r.Peek(2)
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return magic[0] == 0x1f && magic[1] == 0x8b, nil
+}
+
+func isSQLiteHeader(contents []byte) bool {
+	const header = "SQLite format 3\x00"
+	return len(contents) >= len(header) && string(contents[:len(header)]) == header
+}
+
+// checkBatchSizeFlag rejects a --batch-size that would make restoreDump's
+// batching loop misbehave: zero never advances start (an infinite loop), and
+// negative values make end < start, which panics on the statements[start:end]
+// slice.
+func checkBatchSizeFlag() error {
+	if restoreBatchSizeFlag < 1 {
+		return fmt.Errorf("--batch-size must be at least 1")
+	}
+	return nil
+}
+
+// restoreDump splits dump into individual statements and replays them in
+// batches of batchSize so a large restore doesn't ship as a single request.
+// Each batch's response is decoded so a single failing statement (a
+// duplicate row, a constraint violation, bad SQL) fails the restore instead
+// of being silently swallowed.
+func restoreDump(ctx context.Context, url, token, dump string, batchSize int) error {
+	statements, err := splitStatements(dump)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(statements); start += batchSize {
+		end := start + batchSize
+		if end > len(statements) {
+			end = len(statements)
+		}
+
+		batch := joinStatements(statements[start:end])
+		resp, err := doQueryContext(ctx, url, token, batch)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != 200 {
+			resp.Body.Close()
+			return fmt.Errorf("server returned %s", resp.Status)
+		}
+
+		var results []QueryResult
+		err = json.NewDecoder(resp.Body).Decode(&results)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("could not parse response: %w", err)
+		}
+
+		for _, res := range results {
+			if res.Error != nil {
+				return fmt.Errorf("%s", res.Error.Message)
+			}
+		}
+	}
+
+	return nil
+}
+
+func splitStatements(dump string) ([]string, error) {
+	return sqlparser.SplitStatementToPieces(dump)
+}
+
+func joinStatements(statements []string) string {
+	out := ""
+	for _, stmt := range statements {
+		out += stmt + ";\n"
+	}
+	return out
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import "testing"
+
+func TestCheckBatchSizeFlag(t *testing.T) {
+	orig := restoreBatchSizeFlag
+	defer func() { restoreBatchSizeFlag = orig }()
+
+	tests := []struct {
+		name    string
+		size    int
+		wantErr bool
+	}{
+		{"positive", 100, false},
+		{"one", 1, false},
+		{"zero", 0, true},
+		{"negative", -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restoreBatchSizeFlag = tt.size
+			err := checkBatchSizeFlag()
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 
 	"github.com/chiselstrike/iku-turso-cli/internal"
@@ -19,8 +21,19 @@ import (
 	"github.com/xwb1989/sqlparser"
 )
 
+var (
+	shellFileFlag    string
+	shellStdinFlag   bool
+	shellExecuteFlag []string
+	shellFormatFlag  string
+)
+
 func init() {
 	dbCmd.AddCommand(shellCmd)
+	shellCmd.Flags().StringVar(&shellFileFlag, "file", "", "Read SQL statements from a file and run them non-interactively.")
+	shellCmd.Flags().BoolVar(&shellStdinFlag, "stdin", false, "Read SQL statements from standard input and run them non-interactively.")
+	shellCmd.Flags().StringArrayVarP(&shellExecuteFlag, "execute", "e", nil, "Run a single SQL statement non-interactively. Can be repeated.")
+	shellCmd.Flags().StringVar(&shellFormatFlag, "format", "table", "Output format for non-interactive execution: table, json, jsonl, csv, tsv, raw.")
 }
 
 var shellCmd = &cobra.Command{
@@ -37,8 +50,18 @@ var shellCmd = &cobra.Command{
 		}
 		cmd.SilenceUsage = true
 
+		nonInteractive := shellFileFlag != "" || shellStdinFlag || len(shellExecuteFlag) > 0
+
+		profile := resolveProfile()
+		if err := settings.UseSessionProfile(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+		if err := applyProfileCredentials(profile); err != nil {
+			return fmt.Errorf("could not use profile %s: %w", profile, err)
+		}
+
 		spinner := prompt.StoppedSpinner("Connecting to database")
-		if len(args) == 1 {
+		if len(args) == 1 && !nonInteractive {
 			spinner.Start()
 			defer spinner.Stop()
 		}
@@ -63,17 +86,34 @@ var shellCmd = &cobra.Command{
 			return err
 		}
 
+		httpUrl := nameOrUrl
+		if db != nil {
+			httpUrl = getDatabaseHttpUrl(config, db)
+		}
+
+		if nonInteractive {
+			statements, err := gatherStatements(args)
+			if err != nil {
+				return err
+			}
+			return runNonInteractive(cmd.Context(), httpUrl, token, statements, shellFormatFlag)
+		}
+
 		dbUrl := nameOrUrl
 		if db != nil {
-			dbUrl = getDatabaseHttpUrl(config, db)
-			dbUrl = addTokenAsQueryParameter(dbUrl, token)
+			dbUrl = addTokenAsQueryParameter(httpUrl, token)
 		}
 
-		connectionInfo := getConnectionInfo(nameOrUrl, db, config)
+		connectionInfo := getConnectionInfo(nameOrUrl, db, config, profile)
+
+		shellIn := cmd.InOrStdin()
+		if len(args) == 1 {
+			shellIn = wrapWithMetaCommands(cmd.Context(), shellIn, cmd.OutOrStdout(), httpUrl, token)
+		}
 
 		shellConfig := shell.ShellConfig{
 			DbPath:         dbUrl,
-			InF:            cmd.InOrStdin(),
+			InF:            shellIn,
 			OutF:           cmd.OutOrStdout(),
 			ErrF:           cmd.ErrOrStderr(),
 			HistoryMode:    enums.PerDatabaseHistory,
@@ -166,13 +206,17 @@ func tokenFromDb(db *turso.Database, client *turso.Client) (string, error) {
 	return client.Databases.Token(db.Name, "1d", false)
 }
 
-func getConnectionInfo(nameOrUrl string, db *turso.Database, config *settings.Settings) string {
+func getConnectionInfo(nameOrUrl string, db *turso.Database, config *settings.Settings, profile string) string {
 	msg := fmt.Sprintf("Connected to %s", nameOrUrl)
 	if db != nil {
 		url := getDatabaseUrl(config, db, false)
 		msg = fmt.Sprintf("Connected to %s at %s", internal.Emph(db.Name), url)
 	}
 
+	if profile != "" {
+		msg += fmt.Sprintf(" (profile %s)", internal.Emph(profile))
+	}
+
 	msg += "\n\n"
 	msg += "Welcome to Turso SQL shell!\n\n"
 	msg += "Type \".quit\" to exit the shell and \".help\" to list all available commands.\n\n"
@@ -212,3 +256,250 @@ func doQueryContext(ctx context.Context, url, token, stmt string) (*http.Respons
 	}
 	return http.DefaultClient.Do(req)
 }
+
+// gatherStatements collects the SQL to run non-interactively from --file,
+// --stdin, --execute, and the positional argument, in that order.
+func gatherStatements(args []string) ([]string, error) {
+	var statements []string
+
+	if shellFileFlag != "" {
+		contents, err := os.ReadFile(shellFileFlag)
+		if err != nil {
+			return nil, fmt.Errorf("can't read %s: %w", shellFileFlag, err)
+		}
+		statements = append(statements, string(contents))
+	}
+
+	if shellStdinFlag {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("can't read from stdin: %w", err)
+		}
+		statements = append(statements, string(contents))
+	}
+
+	statements = append(statements, shellExecuteFlag...)
+
+	if len(args) == 2 && args[1] != "" {
+		statements = append(statements, args[1])
+	}
+
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no SQL to execute: pass --file, --stdin, --execute, or a SQL argument")
+	}
+
+	return statements, nil
+}
+
+// runNonInteractive executes statements against the database's HTTP query
+// endpoint and renders each result with the formatter for format, bypassing
+// libsql-shell entirely so output can be piped into tools like jq or awk.
+func runNonInteractive(ctx context.Context, url, token string, statements []string, format string) error {
+	formatter, err := formatterFor(format)
+	if err != nil {
+		return err
+	}
+
+	hadError := false
+	for _, stmt := range statements {
+		resp, err := doQueryContext(ctx, url, token, stmt)
+		if err != nil {
+			return err
+		}
+
+		err = decodeQueryResults(resp.Body,
+			func(columns []string, next func() (Row, bool, error)) error {
+				return formatRows(os.Stdout, formatter, columns, next)
+			},
+			func(queryErr *Error) error {
+				fmt.Fprintln(os.Stderr, queryErr.Message)
+				hadError = true
+				return nil
+			},
+		)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("could not parse query response: %w", err)
+		}
+	}
+
+	if hadError {
+		return fmt.Errorf("one or more statements failed")
+	}
+
+	return nil
+}
+
+// formatRows drives a ResultFormatter's Begin/Row/End cycle over a single
+// statement's result, pulling rows one at a time from next instead of
+// collecting them into a slice first.
+func formatRows(w io.Writer, formatter ResultFormatter, columns []string, next func() (Row, bool, error)) error {
+	if err := formatter.Begin(w, columns); err != nil {
+		return err
+	}
+
+	for {
+		row, ok, err := next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		if err := formatter.Row(w, row); err != nil {
+			return err
+		}
+	}
+
+	return formatter.End(w)
+}
+
+// decodeQueryResults reads the JSON array of per-statement results returned
+// by the query endpoint, invoking onResult once per statement that produced
+// a result set and onError once per statement that failed. Rows are decoded
+// one at a time off r rather than being unmarshalled into a []QueryResult
+// up front, so a query with a large result set is never fully buffered in
+// memory before rendering starts.
+//
+// onResult's next function yields one row at a time. onResult need not
+// drain it: any rows left unread when onResult returns are skipped before
+// decoding continues to the next statement.
+func decodeQueryResults(r io.Reader, onResult func(columns []string, next func() (Row, bool, error)) error, onError func(*Error) error) error {
+	dec := json.NewDecoder(r)
+
+	if _, err := dec.Token(); err != nil { // consume opening '['
+		return err
+	}
+
+	for dec.More() {
+		if err := decodeOneResult(dec, onResult, onError); err != nil {
+			return err
+		}
+	}
+
+	_, err := dec.Token() // consume closing ']'
+	return err
+}
+
+func decodeOneResult(dec *json.Decoder, onResult func(columns []string, next func() (Row, bool, error)) error, onError func(*Error) error) error {
+	if _, err := dec.Token(); err != nil { // consume opening '{'
+		return err
+	}
+
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := keyTok.(string)
+
+		switch key {
+		case "error":
+			var queryErr *Error
+			if err := dec.Decode(&queryErr); err != nil {
+				return err
+			}
+			if queryErr != nil {
+				if err := onError(queryErr); err != nil {
+					return err
+				}
+			}
+		case "results":
+			if err := decodeOneResultSet(dec, onResult); err != nil {
+				return err
+			}
+		default:
+			var skip interface{}
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := dec.Token() // consume closing '}'
+	return err
+}
+
+// decodeOneResultSet decodes a "results" value, which is either null (a
+// statement with no result set) or a {columns, rows} object. It assumes the
+// server emits "columns" before "rows", which the query endpoint always
+// does.
+func decodeOneResultSet(dec *json.Decoder, onResult func(columns []string, next func() (Row, bool, error)) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil { // results: null
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("could not parse query response: unexpected results value")
+	}
+
+	var columns []string
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := keyTok.(string)
+
+		switch key {
+		case "columns":
+			if err := dec.Decode(&columns); err != nil {
+				return err
+			}
+		case "rows":
+			if err := decodeRows(dec, columns, onResult); err != nil {
+				return err
+			}
+		default:
+			var skip interface{}
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = dec.Token() // consume closing '}'
+	return err
+}
+
+func decodeRows(dec *json.Decoder, columns []string, onResult func(columns []string, next func() (Row, bool, error)) error) error {
+	tok, err := dec.Token() // consume opening '['
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("could not parse query response: unexpected rows value")
+	}
+
+	var decodeErr error
+	next := func() (Row, bool, error) {
+		if decodeErr != nil || !dec.More() {
+			return nil, false, decodeErr
+		}
+		var row Row
+		if err := dec.Decode(&row); err != nil {
+			decodeErr = err
+			return nil, false, err
+		}
+		return row, true, nil
+	}
+
+	if err := onResult(columns, next); err != nil {
+		return err
+	}
+
+	// Drain any rows the caller didn't consume so the decoder is
+	// positioned at the closing ']' for the token read below.
+	for dec.More() {
+		var skip interface{}
+		if err := dec.Decode(&skip); err != nil {
+			return err
+		}
+	}
+
+	_, err = dec.Token() // consume closing ']'
+	return err
+}
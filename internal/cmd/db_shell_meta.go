@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// metaShellState holds the state that dot-commands can change over the
+// course of an interactive session: the active output format, the timer
+// toggle, and an optional redirected output file.
+type metaShellState struct {
+	ctx   context.Context
+	url   string
+	token string
+	out   io.Writer
+
+	format  string
+	timer   bool
+	outFile *os.File
+}
+
+// wrapWithMetaCommands returns an io.Reader that intercepts psql/sqlite
+// style dot-commands (".tables", ".schema", ...) read from in, running them
+// directly against the query endpoint, and forwards every other line
+// unchanged so libsql-shell can handle it as regular SQL.
+//
+// This only applies when in is not a real terminal: libsql-shell drives a
+// readline session directly off the fd for genuine interactive sessions, and
+// swapping it for an io.Pipe breaks line editing and history. When stdin is
+// a TTY, in is returned unchanged and dot-commands are left to libsql-shell
+// (which will report them as SQL syntax errors).
+func wrapWithMetaCommands(ctx context.Context, in io.Reader, out io.Writer, url, token string) io.Reader {
+	if f, ok := in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		return in
+	}
+
+	state := &metaShellState{ctx: ctx, url: url, token: token, out: out, format: "table"}
+
+	r, w := io.Pipe()
+	go func() {
+		defer w.Close()
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(strings.TrimSpace(line), ".") {
+				if err := state.run(strings.TrimSpace(line)); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+				}
+				continue
+			}
+			if _, err := io.WriteString(w, line+"\n"); err != nil {
+				return
+			}
+		}
+	}()
+
+	return r
+}
+
+func (s *metaShellState) run(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	name, rest := fields[0], fields[1:]
+	switch name {
+	case ".tables":
+		return s.query("SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name")
+	case ".schema":
+		return s.schema(rest)
+	case ".indexes":
+		return s.indexes(rest)
+	case ".dump":
+		return s.dump(rest)
+	case ".import":
+		if len(rest) != 2 {
+			return fmt.Errorf(".import requires a file and a table name")
+		}
+		return s.importCSV(rest[0], rest[1])
+	case ".output":
+		return s.setOutput(rest)
+	case ".timer":
+		return s.setTimer(rest)
+	case ".mode":
+		return s.setMode(rest)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+func (s *metaShellState) schema(rest []string) error {
+	if len(rest) == 0 {
+		return s.query("SELECT sql FROM sqlite_master WHERE sql IS NOT NULL ORDER BY name")
+	}
+	return s.query(fmt.Sprintf("SELECT sql FROM sqlite_master WHERE sql IS NOT NULL AND name = %s", sqlLiteral(rest[0])))
+}
+
+func (s *metaShellState) indexes(rest []string) error {
+	if len(rest) == 0 {
+		return s.query("SELECT name FROM sqlite_master WHERE type = 'index' ORDER BY name")
+	}
+	return s.query(fmt.Sprintf("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = %s ORDER BY name", sqlLiteral(rest[0])))
+}
+
+func (s *metaShellState) dump(rest []string) error {
+	var buf strings.Builder
+
+	if len(rest) == 1 {
+		if err := dumpTableRows(&buf, s.url, s.token, rest[0]); err != nil {
+			return err
+		}
+	} else {
+		schema, tables, err := fetchSchema(s.url, s.token)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(schema)
+		for _, table := range tables {
+			if err := dumpTableRows(&buf, s.url, s.token, table); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := io.WriteString(s.writer(), buf.String())
+	return err
+}
+
+// importCSV streams path as CSV and batches its rows into INSERT statements
+// sent in groups so a large file doesn't ship as one enormous request.
+//
+// The query endpoint takes raw SQL text, not bind parameters, so there is no
+// true parameter binding available here: every value is escaped with
+// sqlLiteral and spliced directly into the statement text.
+func (s *metaShellState) importCSV(path, table string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("can't open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("can't read header from %s: %w", path, err)
+	}
+	cols := strings.Join(header, ", ")
+
+	const batchSize = 200
+	var batch strings.Builder
+	rows := 0
+
+	flush := func() error {
+		if rows == 0 {
+			return nil
+		}
+		resp, err := doQueryContext(s.ctx, s.url, s.token, batch.String())
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != 200 {
+			return fmt.Errorf("server returned %s", resp.Status)
+		}
+
+		var results []QueryResult
+		if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+			return fmt.Errorf("could not parse response: %w", err)
+		}
+		for _, res := range results {
+			if res.Error != nil {
+				return fmt.Errorf("%s", res.Error.Message)
+			}
+		}
+
+		batch.Reset()
+		rows = 0
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("can't read %s: %w", path, err)
+		}
+
+		values := make([]string, len(record))
+		for i, v := range record {
+			values[i] = sqlLiteral(v)
+		}
+		fmt.Fprintf(&batch, "INSERT INTO %s (%s) VALUES (%s);\n", quoteIdent(table), cols, strings.Join(values, ", "))
+		rows++
+
+		if rows >= batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush()
+}
+
+func (s *metaShellState) setOutput(rest []string) error {
+	if s.outFile != nil {
+		s.outFile.Close()
+		s.outFile = nil
+	}
+
+	if len(rest) == 0 || rest[0] == "stdout" {
+		return nil
+	}
+
+	f, err := os.Create(rest[0])
+	if err != nil {
+		return fmt.Errorf("can't create %s: %w", rest[0], err)
+	}
+	s.outFile = f
+	return nil
+}
+
+func (s *metaShellState) setTimer(rest []string) error {
+	if len(rest) != 1 || (rest[0] != "on" && rest[0] != "off") {
+		return fmt.Errorf(".timer requires on or off")
+	}
+	s.timer = rest[0] == "on"
+	return nil
+}
+
+func (s *metaShellState) setMode(rest []string) error {
+	if len(rest) != 1 {
+		return fmt.Errorf(".mode requires a format name")
+	}
+	if _, err := formatterFor(rest[0]); err != nil {
+		return err
+	}
+	s.format = rest[0]
+	return nil
+}
+
+func (s *metaShellState) writer() io.Writer {
+	if s.outFile != nil {
+		return s.outFile
+	}
+	return s.out
+}
+
+func (s *metaShellState) query(stmt string) error {
+	start := time.Now()
+
+	resp, err := doQueryContext(s.ctx, s.url, s.token, stmt)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	formatter, err := formatterFor(s.format)
+	if err != nil {
+		return err
+	}
+
+	out := s.writer()
+	err = decodeQueryResults(resp.Body,
+		func(columns []string, next func() (Row, bool, error)) error {
+			return formatRows(out, formatter, columns, next)
+		},
+		func(queryErr *Error) error {
+			return fmt.Errorf("%s", queryErr.Message)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("could not parse response: %w", err)
+	}
+
+	if s.timer {
+		fmt.Fprintf(out, "Run Time: %.3fs\n", time.Since(start).Seconds())
+	}
+
+	return nil
+}
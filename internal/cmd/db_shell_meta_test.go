@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeCSV(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rows.csv")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("can't write fixture: %v", err)
+	}
+	return path
+}
+
+func TestImportCSVStatementError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"results": null, "error": {"message": "UNIQUE constraint failed"}}]`)
+	}))
+	defer srv.Close()
+
+	path := writeCSV(t, "id,name\n1,alice\n")
+	s := &metaShellState{ctx: context.Background(), url: srv.URL, out: &strings.Builder{}}
+
+	err := s.importCSV(path, "users")
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		t.Fatalf("error = %v, want it to mention the statement error", err)
+	}
+}
+
+func TestImportCSVNonOKStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	path := writeCSV(t, "id,name\n1,alice\n")
+	s := &metaShellState{ctx: context.Background(), url: srv.URL, out: &strings.Builder{}}
+
+	err := s.importCSV(path, "users")
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "500") {
+		t.Fatalf("error = %v, want it to mention the status", err)
+	}
+}
+
+func TestImportCSVSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"results": {"columns": [], "rows": []}, "error": null}]`)
+	}))
+	defer srv.Close()
+
+	path := writeCSV(t, "id,name\n1,alice\n2,bob\n")
+	s := &metaShellState{ctx: context.Background(), url: srv.URL, out: &strings.Builder{}}
+
+	if err := s.importCSV(path, "users"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
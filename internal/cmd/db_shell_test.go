@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeQueryResults(t *testing.T) {
+	body := `[
+		{"results": {"columns": ["a","b"], "rows": [[1,"x"],[2,"y"]]}, "error": null},
+		{"results": null, "error": {"message": "boom"}}
+	]`
+
+	var gotColumns []string
+	var gotRows []Row
+	var gotErrs []string
+
+	err := decodeQueryResults(strings.NewReader(body),
+		func(columns []string, next func() (Row, bool, error)) error {
+			gotColumns = columns
+			for {
+				row, ok, err := next()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				gotRows = append(gotRows, row)
+			}
+			return nil
+		},
+		func(e *Error) error {
+			gotErrs = append(gotErrs, e.Message)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("decodeQueryResults: %v", err)
+	}
+	if len(gotColumns) != 2 || gotColumns[0] != "a" || gotColumns[1] != "b" {
+		t.Fatalf("columns = %v", gotColumns)
+	}
+	if len(gotRows) != 2 {
+		t.Fatalf("rows = %v", gotRows)
+	}
+	if len(gotErrs) != 1 || gotErrs[0] != "boom" {
+		t.Fatalf("errs = %v", gotErrs)
+	}
+}
+
+// TestDecodeQueryResultsPartialConsume checks that decoding can still reach
+// the second statement correctly when onResult stops pulling rows before
+// the first statement's row array is exhausted.
+func TestDecodeQueryResultsPartialConsume(t *testing.T) {
+	body := `[
+		{"results": {"columns": ["a"], "rows": [[1],[2],[3]]}, "error": null},
+		{"results": {"columns": ["b"], "rows": [[9]]}, "error": null}
+	]`
+
+	var secondCols []string
+	var secondRows []Row
+	calls := 0
+
+	err := decodeQueryResults(strings.NewReader(body),
+		func(columns []string, next func() (Row, bool, error)) error {
+			calls++
+			if calls == 1 {
+				_, _, err := next()
+				return err
+			}
+			secondCols = columns
+			for {
+				row, ok, err := next()
+				if err != nil {
+					return err
+				}
+				if !ok {
+					break
+				}
+				secondRows = append(secondRows, row)
+			}
+			return nil
+		},
+		func(e *Error) error { return nil },
+	)
+	if err != nil {
+		t.Fatalf("decodeQueryResults: %v", err)
+	}
+	if len(secondCols) != 1 || secondCols[0] != "b" {
+		t.Fatalf("second columns = %v", secondCols)
+	}
+	if len(secondRows) != 1 {
+		t.Fatalf("second rows = %v", secondRows)
+	}
+}
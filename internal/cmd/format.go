@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// ResultFormatter renders a query result one row at a time: Begin is called
+// once per statement with its columns, Row once per row, and End once rows
+// are exhausted. This lets a large result set be written straight through as
+// it's decoded off the wire instead of being buffered into memory first. The
+// exception is tableFormatter: its tabwriter holds every row until End so it
+// can compute column widths.
+type ResultFormatter interface {
+	Begin(w io.Writer, columns []string) error
+	Row(w io.Writer, row Row) error
+	End(w io.Writer) error
+}
+
+func formatterFor(format string) (ResultFormatter, error) {
+	switch format {
+	case "", "table":
+		return &tableFormatter{}, nil
+	case "json":
+		return &jsonFormatter{}, nil
+	case "jsonl":
+		return &jsonlFormatter{}, nil
+	case "csv":
+		return &delimitedFormatter{comma: ','}, nil
+	case "tsv":
+		return &delimitedFormatter{comma: '\t'}, nil
+	case "raw":
+		return rawFormatter{}, nil
+	case "line":
+		return &lineFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q: must be one of table, json, jsonl, csv, tsv, raw, line", format)
+	}
+}
+
+type tableFormatter struct {
+	tw *tabwriter.Writer
+}
+
+func (f *tableFormatter) Begin(w io.Writer, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+
+	f.tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, col := range columns {
+		if i > 0 {
+			fmt.Fprint(f.tw, "\t")
+		}
+		fmt.Fprint(f.tw, col)
+	}
+	fmt.Fprintln(f.tw)
+	return nil
+}
+
+func (f *tableFormatter) Row(w io.Writer, row Row) error {
+	if f.tw == nil {
+		return nil
+	}
+
+	for i, col := range row {
+		if i > 0 {
+			fmt.Fprint(f.tw, "\t")
+		}
+		fmt.Fprint(f.tw, formatValue(col))
+	}
+	fmt.Fprintln(f.tw)
+	return nil
+}
+
+func (f *tableFormatter) End(w io.Writer) error {
+	if f.tw == nil {
+		return nil
+	}
+	return f.tw.Flush()
+}
+
+// jsonFormatter writes rows as a JSON array, one object appended per Row
+// call, so the array is streamed out without ever holding every row in
+// memory at once.
+type jsonFormatter struct {
+	columns []string
+	first   bool
+}
+
+func (f *jsonFormatter) Begin(w io.Writer, columns []string) error {
+	f.columns = columns
+	f.first = true
+	_, err := io.WriteString(w, "[")
+	return err
+}
+
+func (f *jsonFormatter) Row(w io.Writer, row Row) error {
+	buf, err := json.Marshal(rowAsMap(f.columns, row))
+	if err != nil {
+		return err
+	}
+
+	sep := ","
+	if f.first {
+		sep = ""
+		f.first = false
+	}
+	_, err = fmt.Fprintf(w, "%s\n  %s", sep, buf)
+	return err
+}
+
+func (f *jsonFormatter) End(w io.Writer) error {
+	if f.first {
+		_, err := io.WriteString(w, "]\n")
+		return err
+	}
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
+
+// jsonlFormatter writes one JSON object per row (newline-delimited JSON),
+// so large result sets can be streamed to downstream tools line by line.
+type jsonlFormatter struct {
+	columns []string
+}
+
+func (f *jsonlFormatter) Begin(w io.Writer, columns []string) error {
+	f.columns = columns
+	return nil
+}
+
+func (f *jsonlFormatter) Row(w io.Writer, row Row) error {
+	return json.NewEncoder(w).Encode(rowAsMap(f.columns, row))
+}
+
+func (f *jsonlFormatter) End(w io.Writer) error {
+	return nil
+}
+
+type delimitedFormatter struct {
+	comma rune
+	cw    *csv.Writer
+}
+
+func (f *delimitedFormatter) Begin(w io.Writer, columns []string) error {
+	f.cw = csv.NewWriter(w)
+	f.cw.Comma = f.comma
+	return f.cw.Write(columns)
+}
+
+func (f *delimitedFormatter) Row(w io.Writer, row Row) error {
+	record := make([]string, len(row))
+	for i, col := range row {
+		record[i] = formatValue(col)
+	}
+	return f.cw.Write(record)
+}
+
+func (f *delimitedFormatter) End(w io.Writer) error {
+	f.cw.Flush()
+	return f.cw.Error()
+}
+
+// rawFormatter prints one row per line, its values separated by tabs, with
+// no header or other framing, convenient for piping a single-column result
+// into tools like xargs or awk.
+type rawFormatter struct{}
+
+func (rawFormatter) Begin(w io.Writer, columns []string) error {
+	return nil
+}
+
+func (rawFormatter) Row(w io.Writer, row Row) error {
+	values := make([]string, len(row))
+	for i, col := range row {
+		values[i] = formatValue(col)
+	}
+	_, err := fmt.Fprintln(w, joinTab(values))
+	return err
+}
+
+func (rawFormatter) End(w io.Writer) error {
+	return nil
+}
+
+// lineFormatter prints one "column = value" pair per line with a blank line
+// between rows, matching sqlite3's `.mode line` output.
+type lineFormatter struct {
+	columns []string
+	width   int
+	first   bool
+}
+
+func (f *lineFormatter) Begin(w io.Writer, columns []string) error {
+	f.columns = columns
+	f.first = true
+
+	f.width = 0
+	for _, col := range columns {
+		if len(col) > f.width {
+			f.width = len(col)
+		}
+	}
+	return nil
+}
+
+func (f *lineFormatter) Row(w io.Writer, row Row) error {
+	if !f.first {
+		fmt.Fprintln(w)
+	}
+	f.first = false
+
+	for j, col := range f.columns {
+		value := ""
+		if j < len(row) {
+			value = formatValue(row[j])
+		}
+		fmt.Fprintf(w, "%-*s = %s\n", f.width, col, value)
+	}
+	return nil
+}
+
+func (f *lineFormatter) End(w io.Writer) error {
+	return nil
+}
+
+func rowAsMap(columns []string, row Row) map[string]interface{} {
+	obj := make(map[string]interface{}, len(columns))
+	for i, col := range columns {
+		if i < len(row) {
+			obj[col] = row[i]
+		}
+	}
+	return obj
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func joinTab(values []string) string {
+	out := ""
+	for i, v := range values {
+		if i > 0 {
+			out += "\t"
+		}
+		out += v
+	}
+	return out
+}
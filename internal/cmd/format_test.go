@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func runFormatter(t *testing.T, f ResultFormatter, columns []string, rows []Row) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := f.Begin(&buf, columns); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	for _, row := range rows {
+		if err := f.Row(&buf, row); err != nil {
+			t.Fatalf("Row: %v", err)
+		}
+	}
+	if err := f.End(&buf); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	return buf.String()
+}
+
+func TestJSONLFormatter(t *testing.T) {
+	out := runFormatter(t, &jsonlFormatter{}, []string{"id"}, []Row{{1}, {2}})
+	var lines []string
+	for _, l := range bytes.Split([]byte(out), []byte("\n")) {
+		if len(l) > 0 {
+			lines = append(lines, string(l))
+		}
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), out)
+	}
+}
+
+func TestDelimitedFormatterCSV(t *testing.T) {
+	out := runFormatter(t, &delimitedFormatter{comma: ','}, []string{"a", "b"}, []Row{{1, "x"}})
+	want := "a,b\n1,x\n"
+	if out != want {
+		t.Fatalf("got %q, want %q", out, want)
+	}
+}
+
+func TestLineFormatterSeparatesRows(t *testing.T) {
+	out := runFormatter(t, &lineFormatter{}, []string{"a"}, []Row{{1}, {2}})
+	want := "a = 1\n\na = 2\n"
+	if out != want {
+		t.Fatalf("got %q, want %q", out, want)
+	}
+}
+
+func TestJSONFormatterEmpty(t *testing.T) {
+	out := runFormatter(t, &jsonFormatter{}, []string{"a"}, nil)
+	if out != "[]\n" {
+		t.Fatalf("got %q, want %q", out, "[]\n")
+	}
+}
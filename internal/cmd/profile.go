@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chiselstrike/iku-turso-cli/internal"
+	"github.com/chiselstrike/iku-turso-cli/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+var profileFlag string
+
+func init() {
+	dbCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Named profile to use for this command. Overrides TURSO_PROFILE and the active profile.")
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+
+	profileCreateCmd.Flags().StringVar(&profileTokenFlag, "token", "", "Access token for this profile.")
+	profileCreateCmd.Flags().StringVar(&profileRegionFlag, "region", "", "Default region for databases created under this profile.")
+	profileCreateCmd.Flags().StringVar(&profileURLFlag, "url", "", "API base URL for this profile, for self-hosted Turso instances. Defaults to the standard Turso API.")
+}
+
+var profileTokenFlag string
+var profileRegionFlag string
+var profileURLFlag string
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named connection profiles.",
+	Long:  "Manage named connection profiles, each with its own access token and default region.\nThis lets you switch between multiple Turso accounts with --profile or the TURSO_PROFILE environment variable, similar to aws-cli or gcloud.",
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a new profile.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		name := args[0]
+
+		if profileTokenFlag == "" {
+			return fmt.Errorf("--token is required")
+		}
+
+		if err := settings.CreateProfile(name, profileTokenFlag, profileRegionFlag, profileURLFlag); err != nil {
+			return fmt.Errorf("could not create profile %s: %w", name, err)
+		}
+
+		fmt.Printf("Created profile %s.\n", internal.Emph(name))
+		return nil
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available profiles.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		active := resolveProfile()
+
+		profiles := settings.ListProfiles()
+		if len(profiles) == 0 {
+			fmt.Println("No profiles configured.")
+			return nil
+		}
+
+		for _, p := range profiles {
+			marker := " "
+			if p.Name == active {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\n", marker, p.Name)
+		}
+
+		return nil
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the active profile.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		name := args[0]
+
+		if err := settings.UseProfile(name); err != nil {
+			return fmt.Errorf("could not switch to profile %s: %w", name, err)
+		}
+
+		fmt.Printf("Now using profile %s.\n", internal.Emph(name))
+		return nil
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		name := args[0]
+
+		if err := settings.DeleteProfile(name); err != nil {
+			return fmt.Errorf("could not delete profile %s: %w", name, err)
+		}
+
+		fmt.Printf("Deleted profile %s.\n", internal.Emph(name))
+		return nil
+	},
+}
+
+// resolveProfile returns the profile that commands should use for this
+// invocation: --profile takes precedence over TURSO_PROFILE, which in turn
+// takes precedence over whatever profile was last selected with `profile use`.
+func resolveProfile() string {
+	if profileFlag != "" {
+		return profileFlag
+	}
+	if env := os.Getenv("TURSO_PROFILE"); env != "" {
+		return env
+	}
+	return settings.ActiveProfileName()
+}
+
+// applyProfileCredentials points createTursoClient/createTursoClientFromAccessToken
+// at the named profile's account for the rest of this process, by setting
+// the same environment variables those use to read an access token and API
+// base URL override (TURSO_API_TOKEN, TURSO_API_BASE_URL). Without this,
+// --profile/TURSO_PROFILE/`profile use` only changed the display string in
+// getConnectionInfo and never the credentials actually used for API calls.
+// An empty name or "default" is a no-op, matching UseSessionProfile.
+func applyProfileCredentials(name string) error {
+	if name == "" || name == "default" {
+		return nil
+	}
+
+	profile, ok := settings.ProfileByName(name)
+	if !ok {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+
+	if profile.Token != "" {
+		os.Setenv("TURSO_API_TOKEN", profile.Token)
+	}
+	if profile.URL != "" {
+		os.Setenv("TURSO_API_BASE_URL", profile.URL)
+	}
+
+	return nil
+}
+
+// defaultRegionForProfile returns the profile's configured default region,
+// or "" if none is set (or no profile is active), so commands can fall back
+// to it before probing for the closest region.
+func defaultRegionForProfile(name string) string {
+	if name == "" || name == "default" {
+		return ""
+	}
+
+	profile, ok := settings.ProfileByName(name)
+	if !ok {
+		return ""
+	}
+
+	return profile.Region
+}
@@ -0,0 +1,31 @@
+package settings
+
+// BackupSettings records metadata about one backup taken with
+// `turso db backup`, so `turso db backup list` can enumerate prior backups
+// for a database without re-reading every file on disk.
+type BackupSettings struct {
+	Database  string `json:"database"`
+	Region    string `json:"region,omitempty"`
+	Path      string `json:"path"`
+	Format    string `json:"format"`
+	Size      int64  `json:"size"`
+	Checksum  string `json:"checksum"`
+	Timestamp string `json:"timestamp"`
+}
+
+// AddBackup records a completed backup of dbName.
+func (s *Settings) AddBackup(dbName string, backup *BackupSettings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Backups[dbName] = append(s.data.Backups[dbName], *backup)
+	s.save()
+}
+
+// ListBackups returns the backups recorded for dbName, oldest first.
+func (s *Settings) ListBackups(dbName string) []BackupSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]BackupSettings(nil), s.data.Backups[dbName]...)
+}
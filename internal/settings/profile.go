@@ -0,0 +1,156 @@
+package settings
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Profile stores the credentials for one named Turso account, so a single
+// machine can hold connection info for multiple customers or environments
+// and switch between them with `turso profile use` or --profile, mirroring
+// named profiles in aws-cli and gcloud.
+type Profile struct {
+	Name   string `json:"name"`
+	Token  string `json:"token"`
+	Region string `json:"region,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// CreateProfile adds a new named profile, overwriting any existing profile
+// with the same name. url overrides the API base URL for this profile
+// (e.g. a self-hosted Turso instance); leave it empty to use the default.
+func CreateProfile(name, token, region, url string) error {
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+	if token == "" {
+		return fmt.Errorf("profile token cannot be empty")
+	}
+
+	s, err := ReadSettings()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Profiles[name] = Profile{Name: name, Token: token, Region: region, URL: url}
+	return s.save()
+}
+
+// ProfileByName returns the named profile, or ok=false if it doesn't exist.
+func ProfileByName(name string) (Profile, bool) {
+	s, err := ReadSettings()
+	if err != nil {
+		return Profile{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.data.Profiles[name]
+	return p, ok
+}
+
+// ListProfiles returns every configured profile, sorted by name.
+func ListProfiles() []Profile {
+	s, err := ReadSettings()
+	if err != nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles := make([]Profile, 0, len(s.data.Profiles))
+	for _, p := range s.data.Profiles {
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+
+	return profiles
+}
+
+// UseProfile persists name as the active profile for future invocations.
+func UseProfile(name string) error {
+	s, err := ReadSettings()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+
+	s.data.ActiveProfile = name
+	return s.save()
+}
+
+// DeleteProfile removes a profile, clearing it as the active profile if it
+// was selected.
+func DeleteProfile(name string) error {
+	s, err := ReadSettings()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+
+	delete(s.data.Profiles, name)
+	if s.data.ActiveProfile == name {
+		s.data.ActiveProfile = ""
+	}
+
+	return s.save()
+}
+
+// ActiveProfileName returns the profile persisted by `turso profile use`, or
+// "default" if none has been selected.
+func ActiveProfileName() string {
+	s, err := ReadSettings()
+	if err != nil {
+		return "default"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data.ActiveProfile == "" {
+		return "default"
+	}
+
+	return s.data.ActiveProfile
+}
+
+// UseSessionProfile overrides the active profile for the lifetime of this
+// process only (used to implement --profile/TURSO_PROFILE), without
+// persisting the choice to disk. An empty name or "default" is a no-op.
+func UseSessionProfile(name string) error {
+	if name == "" || name == "default" {
+		return nil
+	}
+
+	s, err := ReadSettings()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q does not exist", name)
+	}
+
+	s.data.ActiveProfile = name
+	return nil
+}
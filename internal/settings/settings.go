@@ -0,0 +1,143 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Settings is the in-memory, file-backed configuration for the turso CLI.
+// Every command goes through ReadSettings so they all observe the same
+// on-disk state, including changes made earlier in the same process (e.g.
+// `turso profile use` taking effect for the rest of that invocation).
+type Settings struct {
+	mu   sync.Mutex
+	path string
+	data settingsData
+}
+
+type settingsData struct {
+	Databases      map[string]DatabaseSettings `json:"databases"`
+	RegisteredUses map[string]bool             `json:"registered_uses"`
+	DbNamesCache   []string                    `json:"db_names_cache,omitempty"`
+	Profiles       map[string]Profile          `json:"profiles"`
+	ActiveProfile  string                      `json:"active_profile"`
+	Backups        map[string][]BackupSettings `json:"backups"`
+}
+
+// DatabaseSettings holds the credentials issued for a database at creation
+// time, keyed by database ID in Settings.Databases.
+type DatabaseSettings struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+var (
+	once     sync.Once
+	instance *Settings
+	initErr  error
+)
+
+// ReadSettings loads the on-disk settings file, starting from empty settings
+// if it doesn't exist yet (the file is only written by the first save). The
+// loaded instance is cached for the lifetime of the process.
+func ReadSettings() (*Settings, error) {
+	once.Do(func() {
+		path, err := settingsPath()
+		if err != nil {
+			initErr = err
+			return
+		}
+
+		s := &Settings{path: path, data: emptySettingsData()}
+
+		contents, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(contents, &s.data); err != nil {
+				initErr = fmt.Errorf("could not parse %s: %w", path, err)
+				return
+			}
+		case os.IsNotExist(err):
+			// No settings file yet; start from the empty default.
+		default:
+			initErr = fmt.Errorf("could not read %s: %w", path, err)
+			return
+		}
+
+		instance = s
+	})
+
+	return instance, initErr
+}
+
+func emptySettingsData() settingsData {
+	return settingsData{
+		Databases:      map[string]DatabaseSettings{},
+		RegisteredUses: map[string]bool{},
+		Profiles:       map[string]Profile{},
+		Backups:        map[string][]BackupSettings{},
+	}
+}
+
+func settingsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine home directory: %w", err)
+	}
+
+	dir := filepath.Join(home, ".turso")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("could not create %s: %w", dir, err)
+	}
+
+	return filepath.Join(dir, "settings.json"), nil
+}
+
+// save persists the current settings to disk. Callers must hold s.mu.
+func (s *Settings) save() error {
+	contents, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, contents, 0o600)
+}
+
+// AddDatabase records the credentials issued for a newly created database so
+// later commands can look them up by ID.
+func (s *Settings) AddDatabase(ID string, db *DatabaseSettings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Databases[ID] = *db
+	s.save()
+}
+
+// InvalidateDbNamesCache clears any cached listing of database names so the
+// next name completion or lookup re-fetches from the API.
+func (s *Settings) InvalidateDbNamesCache() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.DbNamesCache = nil
+	s.save()
+}
+
+// RegisterUse marks cmdName as having been run at least once, returning true
+// only the first time it's called for that name, so callers can show
+// onboarding hints exactly once.
+func (s *Settings) RegisterUse(cmdName string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data.RegisteredUses[cmdName] {
+		return false
+	}
+
+	s.data.RegisteredUses[cmdName] = true
+	s.save()
+	return true
+}